@@ -0,0 +1,51 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+// RouteEventKind identifies the kind of change a RouteEvent represents.
+type RouteEventKind int
+
+const (
+	// Added indicates the Route became reachable, i.e. a new best-path.
+	Added RouteEventKind = iota
+	// Withdrawn indicates a previously announced Route is no longer reachable.
+	Withdrawn
+)
+
+// RouteEvent reports a single reachability change for a route of any
+// supported family, as observed by the BGP speaker.
+type RouteEvent struct {
+	Kind     RouteEventKind
+	Route    Route
+	PathUUID PathUUID
+}
+
+// PeerState is the FSM state of a BGP neighbor session, as reported by
+// Session.WatchPeerState.
+type PeerState string
+
+// Peer states relevant to subscribers; these mirror the terminal/visible
+// states of the BGP neighbor FSM.
+const (
+	PeerStateIdle        PeerState = "Idle"
+	PeerStateActive      PeerState = "Active"
+	PeerStateEstablished PeerState = "Established"
+)
+
+// PeerEvent reports a BGP neighbor state transition.
+type PeerEvent struct {
+	Neighbor string
+	State    PeerState
+}
@@ -0,0 +1,154 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execbgp
+
+import (
+	"net/rpc"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+)
+
+// rpcClient runs in the agent process; it is the bgp.Speaker view of the bgp-speaker child process.
+type rpcClient struct {
+	client *rpc.Client
+	broker *plugin.MuxBroker
+}
+
+// Start implements bgp.Speaker.
+func (c *rpcClient) Start(global *config.Global) (bgp.Session, error) {
+	if err := c.client.Call("Plugin.Start", global, nil); err != nil {
+		return nil, err
+	}
+	return &rpcSession{client: c.client, broker: c.broker}, nil
+}
+
+// Stop implements bgp.Speaker.
+func (c *rpcClient) Stop() error {
+	return c.client.Call("Plugin.Stop", struct{}{}, nil)
+}
+
+// rpcSession is the agent-side bgp.Session view of the session running in the bgp-speaker child process.
+type rpcSession struct {
+	client *rpc.Client
+	broker *plugin.MuxBroker
+}
+
+// AddNeighbor implements bgp.Session.
+func (s *rpcSession) AddNeighbor(neighbor *config.Neighbor) error {
+	return s.client.Call("Plugin.AddNeighbor", &addNeighborArgs{Neighbor: neighbor}, nil)
+}
+
+// DeleteNeighbor implements bgp.Session.
+func (s *rpcSession) DeleteNeighbor(addr string) error {
+	return s.client.Call("Plugin.DeleteNeighbor", addr, nil)
+}
+
+// WatchBestPath implements bgp.Session. It hands the child process a broker ID to call back into
+// with every best-path event it observes.
+func (s *rpcSession) WatchBestPath(callback func(bgp.Path)) (bgp.Watch, error) {
+	brokerID := s.broker.NextId()
+	go s.broker.AcceptAndServe(brokerID, &pathCallbackServer{callback: callback})
+	if err := s.client.Call("Plugin.WatchBestPath", brokerID, nil); err != nil {
+		return nil, err
+	}
+	return &noopWatch{}, nil
+}
+
+// WatchPeerState implements bgp.Session. It hands the child process a broker ID to call back into
+// with every peer state event it observes.
+func (s *rpcSession) WatchPeerState(callback func(bgp.PeerEvent)) (bgp.Watch, error) {
+	brokerID := s.broker.NextId()
+	go s.broker.AcceptAndServe(brokerID, &peerCallbackServer{callback: callback})
+	if err := s.client.Call("Plugin.WatchPeerState", brokerID, nil); err != nil {
+		return nil, err
+	}
+	return &noopWatch{}, nil
+}
+
+// AnnouncePath implements bgp.Session.
+func (s *rpcSession) AnnouncePath(route bgp.AnnouncedRoute) (bgp.PathHandle, error) {
+	var uuid bgp.PathUUID
+	if err := s.client.Call("Plugin.AnnouncePath", &announceArgs{Route: route}, &uuid); err != nil {
+		return nil, err
+	}
+	return &rpcPathHandle{client: s.client, uuid: uuid}, nil
+}
+
+// Close implements bgp.Session. The underlying session lives for as long as the bgp-speaker child
+// process does, so there is nothing to do here beyond what Speaker.Stop already tears down.
+func (s *rpcSession) Close() error {
+	return nil
+}
+
+// noopWatch is the bgp.Watch returned for a watch backed by the broker: events keep flowing to the
+// callback server for as long as the child process (and thus the broker connection) is alive, and
+// Speaker.Stop is what ultimately ends that.
+type noopWatch struct{}
+
+// Stop implements bgp.Watch.
+func (w *noopWatch) Stop() {}
+
+// pathCallbackServer is the net/rpc service the child process calls back into for best-path events.
+type pathCallbackServer struct {
+	callback func(bgp.Path)
+}
+
+// OnPath is called by the bgp-speaker child process for every best-path event.
+func (s *pathCallbackServer) OnPath(event *pathEvent, _ *struct{}) error {
+	s.callback(&rpcPath{event: *event})
+	return nil
+}
+
+// peerCallbackServer is the net/rpc service the child process calls back into for peer state events.
+type peerCallbackServer struct {
+	callback func(bgp.PeerEvent)
+}
+
+// OnPeer is called by the bgp-speaker child process for every neighbor state transition.
+func (s *peerCallbackServer) OnPeer(event *bgp.PeerEvent, _ *struct{}) error {
+	s.callback(*event)
+	return nil
+}
+
+// rpcPath adapts a pathEvent received over RPC to bgp.Path.
+type rpcPath struct {
+	event pathEvent
+}
+
+// UUID implements bgp.Path.
+func (p *rpcPath) UUID() bgp.PathUUID { return p.event.UUID }
+
+// IsWithdraw implements bgp.Path.
+func (p *rpcPath) IsWithdraw() bool { return p.event.IsWithdraw }
+
+// Route implements bgp.Path.
+func (p *rpcPath) Route() (bgp.Route, error) { return p.event.Route, nil }
+
+// rpcPathHandle adapts an originated path's UUID to bgp.PathHandle, calling back into the child
+// process to withdraw it.
+type rpcPathHandle struct {
+	client *rpc.Client
+	uuid   bgp.PathUUID
+}
+
+// UUID implements bgp.PathHandle.
+func (h *rpcPathHandle) UUID() bgp.PathUUID { return h.uuid }
+
+// Withdraw implements bgp.PathHandle.
+func (h *rpcPathHandle) Withdraw() error {
+	return h.client.Call("Plugin.WithdrawPath", h.uuid, nil)
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package execbgp implements bgp.Speaker by running the BGP implementation
+// in a separate "bgp-speaker" child process and talking to it over an RPC
+// boundary built on hashicorp/go-plugin's net/rpc transport, with
+// go-plugin's MuxBroker used to stream path and peer events back to this
+// process. This isolates gobgp crashes and resource leaks from the agent
+// process, and lets privileges like CAP_NET_BIND_SERVICE be granted to the
+// child alone rather than to the whole agent.
+package execbgp
+
+import (
+	"encoding/gob"
+	"net/rpc"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+)
+
+func init() {
+	// bgp.Route is carried as an interface{} over gob; every concrete
+	// implementation has to be registered so the wire format round-trips.
+	gob.Register(bgp.IPv4UnicastRoute{})
+	gob.Register(bgp.IPv6UnicastRoute{})
+	gob.Register(bgp.VPNv4Route{})
+	gob.Register(bgp.EVPNRoute{})
+}
+
+// Handshake is the shared handshake config both the agent and the
+// bgp-speaker child must agree on before a connection is trusted.
+var Handshake = plugin.HandshakeConfig{
+	ProtocolVersion:  1,
+	MagicCookieKey:   "BGP_AGENT_SPEAKER_PLUGIN",
+	MagicCookieValue: "bgp-agent-speaker",
+}
+
+// pluginMap is the set of plugins exposed over the RPC boundary; "speaker" is the only one.
+var pluginMap = map[string]plugin.Plugin{
+	"speaker": &SpeakerPlugin{},
+}
+
+// pathEvent is the wire representation of a bgp.Path best-path event.
+type pathEvent struct {
+	UUID       bgp.PathUUID
+	IsWithdraw bool
+	Route      bgp.Route
+}
+
+// addNeighborArgs are the RPC arguments for rpcServer.AddNeighbor.
+type addNeighborArgs struct {
+	Neighbor *config.Neighbor
+}
+
+// announceArgs are the RPC arguments for rpcServer.AnnouncePath.
+type announceArgs struct {
+	Route bgp.AnnouncedRoute
+}
+
+// SpeakerPlugin is the hashicorp/go-plugin net/rpc Plugin implementation
+// shared by the agent (client side) and the bgp-speaker binary (server
+// side).
+type SpeakerPlugin struct {
+	// Manager backs the server side; it is nil on the client side.
+	Manager bgp.RouterManager
+}
+
+// Server implements plugin.Plugin; it runs in the bgp-speaker child process.
+func (p *SpeakerPlugin) Server(broker *plugin.MuxBroker) (interface{}, error) {
+	return &rpcServer{manager: p.Manager, broker: broker}, nil
+}
+
+// Client implements plugin.Plugin; it runs in the agent process.
+func (p *SpeakerPlugin) Client(broker *plugin.MuxBroker, client *rpc.Client) (interface{}, error) {
+	return &rpcClient{client: client, broker: broker}, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execbgp
+
+import (
+	"net"
+	"net/rpc"
+	"sync"
+
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+)
+
+// broker is the subset of *plugin.MuxBroker that rpcServer needs to dial
+// back into the client for path and peer event callbacks. It exists so
+// tests can drive WatchBestPath/WatchPeerState against a fake connection
+// instead of a real go-plugin RPC boundary.
+type broker interface {
+	Dial(id uint32) (net.Conn, error)
+}
+
+// rpcServer runs in the bgp-speaker child process. It wraps the real
+// bgp.RouterManager (backed by bgp/gobgp) and exposes it to the agent
+// process over net/rpc.
+type rpcServer struct {
+	manager bgp.RouterManager
+	broker  broker
+
+	mu      sync.Mutex
+	session bgp.Session
+	paths   map[bgp.PathUUID]bgp.PathHandle // originated paths, for WithdrawPath
+}
+
+// Start implements the server side of Speaker.Start.
+func (s *rpcServer) Start(global *config.Global, _ *struct{}) error {
+	session, err := s.manager.Start(global)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.session = session
+	s.paths = map[bgp.PathUUID]bgp.PathHandle{}
+	s.mu.Unlock()
+	return nil
+}
+
+// Stop implements the server side of Speaker.Stop.
+func (s *rpcServer) Stop(_ struct{}, _ *struct{}) error {
+	return s.manager.Stop()
+}
+
+// AddNeighbor implements the server side of Session.AddNeighbor.
+func (s *rpcServer) AddNeighbor(args *addNeighborArgs, _ *struct{}) error {
+	return s.session.AddNeighbor(args.Neighbor)
+}
+
+// DeleteNeighbor implements the server side of Session.DeleteNeighbor.
+func (s *rpcServer) DeleteNeighbor(addr string, _ *struct{}) error {
+	return s.session.DeleteNeighbor(addr)
+}
+
+// AnnouncePath implements the server side of Session.AnnouncePath.
+func (s *rpcServer) AnnouncePath(args *announceArgs, reply *bgp.PathUUID) error {
+	handle, err := s.session.AnnouncePath(args.Route)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.paths[handle.UUID()] = handle
+	s.mu.Unlock()
+	*reply = handle.UUID()
+	return nil
+}
+
+// WithdrawPath implements the server side of PathHandle.Withdraw, looking up the handle
+// that AnnouncePath produced earlier.
+func (s *rpcServer) WithdrawPath(uuid bgp.PathUUID, _ *struct{}) error {
+	s.mu.Lock()
+	handle, ok := s.paths[uuid]
+	s.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	return handle.Withdraw()
+}
+
+// WatchBestPath implements the server side of Session.WatchBestPath. brokerID identifies the
+// connection the client is listening on for pathEvent callbacks.
+func (s *rpcServer) WatchBestPath(brokerID uint32, _ *struct{}) error {
+	conn, err := s.broker.Dial(brokerID)
+	if err != nil {
+		return err
+	}
+	client := rpc.NewClient(conn)
+	_, err = s.session.WatchBestPath(func(path bgp.Path) {
+		route, err := path.Route()
+		if err != nil {
+			return
+		}
+		event := pathEvent{UUID: path.UUID(), IsWithdraw: path.IsWithdraw(), Route: route}
+		client.Go("Plugin.OnPath", &event, nil, nil)
+	})
+	return err
+}
+
+// WatchPeerState implements the server side of Session.WatchPeerState. brokerID identifies the
+// connection the client is listening on for bgp.PeerEvent callbacks.
+func (s *rpcServer) WatchPeerState(brokerID uint32, _ *struct{}) error {
+	conn, err := s.broker.Dial(brokerID)
+	if err != nil {
+		return err
+	}
+	client := rpc.NewClient(conn)
+	_, err = s.session.WatchPeerState(func(event bgp.PeerEvent) {
+		client.Go("Plugin.OnPeer", &event, nil, nil)
+	})
+	return err
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execbgp
+
+import (
+	"net"
+	"net/rpc"
+	"testing"
+	"time"
+
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+)
+
+// pipeBroker is a fake broker backed by a single net.Pipe, standing in for
+// the connection a real *plugin.MuxBroker.Dial would hand back. It exists so
+// rpcServer.WatchBestPath/WatchPeerState can be exercised without spawning a
+// real go-plugin child process.
+type pipeBroker struct {
+	conn net.Conn
+}
+
+func (b *pipeBroker) Dial(uint32) (net.Conn, error) { return b.conn, nil }
+
+// servePluginRPC registers v under the "Plugin" service name and serves it
+// on conn, mirroring how plugin.MuxBroker.AcceptAndServe registers the
+// client-side callback servers in the real RPC boundary.
+func servePluginRPC(conn net.Conn, v interface{}) {
+	server := rpc.NewServer()
+	server.RegisterName("Plugin", v)
+	go server.ServeConn(conn)
+}
+
+// fakeSession is a minimal bgp.Session that hands its WatchBestPath/
+// WatchPeerState callback straight back to the test.
+type fakeSession struct {
+	pathCB func(bgp.Path)
+	peerCB func(bgp.PeerEvent)
+}
+
+func (s *fakeSession) AddNeighbor(*config.Neighbor) error { return nil }
+func (s *fakeSession) DeleteNeighbor(string) error        { return nil }
+
+func (s *fakeSession) WatchBestPath(cb func(bgp.Path)) (bgp.Watch, error) {
+	s.pathCB = cb
+	return &noopWatch{}, nil
+}
+
+func (s *fakeSession) WatchPeerState(cb func(bgp.PeerEvent)) (bgp.Watch, error) {
+	s.peerCB = cb
+	return &noopWatch{}, nil
+}
+
+func (s *fakeSession) AnnouncePath(bgp.AnnouncedRoute) (bgp.PathHandle, error) { return nil, nil }
+func (s *fakeSession) Close() error                                            { return nil }
+
+// fakePath is a minimal bgp.Path used to drive a best-path event through the
+// fake session.
+type fakePath struct {
+	uuid  bgp.PathUUID
+	route bgp.Route
+}
+
+func (p *fakePath) UUID() bgp.PathUUID        { return p.uuid }
+func (p *fakePath) IsWithdraw() bool          { return false }
+func (p *fakePath) Route() (bgp.Route, error) { return p.route, nil }
+
+// TestRpcServerWatchBestPathDeliversEvents drives a best-path event through
+// rpcServer.WatchBestPath and across a broker-dialed connection to the
+// client-side callback server. It guards against the callback RPC being
+// addressed to the wrong net/rpc service name: the client registers its
+// callback servers under "Plugin" (as plugin.MuxBroker.AcceptAndServe does),
+// not "Callback".
+func TestRpcServerWatchBestPathDeliversEvents(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+
+	received := make(chan bgp.Path, 1)
+	servePluginRPC(clientConn, &pathCallbackServer{callback: func(p bgp.Path) {
+		received <- p
+	}})
+
+	session := &fakeSession{}
+	srv := &rpcServer{broker: &pipeBroker{conn: serverConn}, session: session}
+
+	if err := srv.WatchBestPath(0, &struct{}{}); err != nil {
+		t.Fatalf("WatchBestPath: %v", err)
+	}
+
+	session.pathCB(&fakePath{uuid: "uuid-1", route: bgp.IPv4UnicastRoute{}})
+
+	select {
+	case p := <-received:
+		if p.UUID() != "uuid-1" {
+			t.Fatalf("got UUID %q, want %q", p.UUID(), "uuid-1")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for best-path event to reach the callback server")
+	}
+}
+
+// TestRpcServerWatchPeerStateDeliversEvents is the WatchPeerState analogue of
+// TestRpcServerWatchBestPathDeliversEvents.
+func TestRpcServerWatchPeerStateDeliversEvents(t *testing.T) {
+	serverConn, clientConn := net.Pipe()
+
+	received := make(chan bgp.PeerEvent, 1)
+	servePluginRPC(clientConn, &peerCallbackServer{callback: func(e bgp.PeerEvent) {
+		received <- e
+	}})
+
+	session := &fakeSession{}
+	srv := &rpcServer{broker: &pipeBroker{conn: serverConn}, session: session}
+
+	if err := srv.WatchPeerState(0, &struct{}{}); err != nil {
+		t.Fatalf("WatchPeerState: %v", err)
+	}
+
+	session.peerCB(bgp.PeerEvent{Neighbor: "10.0.0.1"})
+
+	select {
+	case e := <-received:
+		if e.Neighbor != "10.0.0.1" {
+			t.Fatalf("got Neighbor %q, want %q", e.Neighbor, "10.0.0.1")
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("timed out waiting for peer state event to reach the callback server")
+	}
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package execbgp
+
+import (
+	"errors"
+	"os/exec"
+
+	"github.com/hashicorp/go-plugin"
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+)
+
+// errInvalidPluginType is returned when the dispensed plugin does not implement *rpcClient, which
+// would indicate a mismatch between the agent and bgp-speaker binaries.
+var errInvalidPluginType = errors.New("execbgp: speaker plugin returned an unexpected type")
+
+// execSpeaker is the bgp.Speaker implementation that runs the BGP speaker as a "bgp-speaker" child
+// process reached over RPC, instead of linking gobgp into the agent's own address space.
+type execSpeaker struct {
+	execPath string
+	client   *plugin.Client
+	speaker  *rpcClient
+}
+
+// NewSpeaker creates a bgp.Speaker that spawns the binary at execPath as a bgp-speaker child process
+// on Start and talks to it over hashicorp/go-plugin's net/rpc transport.
+func NewSpeaker(execPath string) bgp.Speaker {
+	return &execSpeaker{execPath: execPath}
+}
+
+// Start implements bgp.Speaker. It launches the child process, performs the go-plugin handshake, and
+// forwards Start to it.
+func (s *execSpeaker) Start(global *config.Global) (bgp.Session, error) {
+	s.client = plugin.NewClient(&plugin.ClientConfig{
+		HandshakeConfig: Handshake,
+		Plugins:         pluginMap,
+		Cmd:             exec.Command(s.execPath),
+	})
+
+	rpcClientProtocol, err := s.client.Client()
+	if err != nil {
+		s.client.Kill()
+		return nil, err
+	}
+
+	raw, err := rpcClientProtocol.Dispense("speaker")
+	if err != nil {
+		s.client.Kill()
+		return nil, err
+	}
+
+	speaker, ok := raw.(*rpcClient)
+	if !ok {
+		s.client.Kill()
+		return nil, errInvalidPluginType
+	}
+	s.speaker = speaker
+
+	return speaker.Start(global)
+}
+
+// Stop implements bgp.Speaker. It tells the bgp-speaker child to stop its BGP session and then kills
+// the child process.
+func (s *execSpeaker) Stop() error {
+	defer s.client.Kill()
+	return s.speaker.Stop()
+}
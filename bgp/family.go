@@ -0,0 +1,97 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+// Family identifies an AFI/SAFI combination carried by a BGP path.
+type Family int
+
+const (
+	// FamilyIPv4Unicast is the IPv4 unicast AFI/SAFI.
+	FamilyIPv4Unicast Family = iota
+	// FamilyIPv6Unicast is the IPv6 unicast AFI/SAFI.
+	FamilyIPv6Unicast
+	// FamilyVPNv4 is the MPLS-labeled VPNv4 (L3VPN) AFI/SAFI.
+	FamilyVPNv4
+	// FamilyEVPN is the L2VPN EVPN AFI/SAFI.
+	FamilyEVPN
+)
+
+// Route is implemented by every family-specific route representation
+// decoded from a BGP path by the gobgp driver's family-aware decoder.
+type Route interface {
+	// Family identifies which of IPv4UnicastRoute, IPv6UnicastRoute,
+	// VPNv4Route or EVPNRoute this value is.
+	Family() Family
+	// ASPath returns the path's AS numbers in traversal order, closest
+	// origin segment first.
+	ASPath() []uint32
+}
+
+// IPv4UnicastRoute is a decoded IPv4 unicast NLRI.
+type IPv4UnicastRoute struct {
+	Prefix  string
+	Nexthop string
+	As      []uint32
+}
+
+// Family implements Route.
+func (r IPv4UnicastRoute) Family() Family { return FamilyIPv4Unicast }
+
+// ASPath implements Route.
+func (r IPv4UnicastRoute) ASPath() []uint32 { return r.As }
+
+// IPv6UnicastRoute is a decoded IPv6 unicast NLRI.
+type IPv6UnicastRoute struct {
+	Prefix  string
+	Nexthop string
+	As      []uint32
+}
+
+// Family implements Route.
+func (r IPv6UnicastRoute) Family() Family { return FamilyIPv6Unicast }
+
+// ASPath implements Route.
+func (r IPv6UnicastRoute) ASPath() []uint32 { return r.As }
+
+// VPNv4Route is a decoded MPLS-labeled VPNv4 (L3VPN) NLRI.
+type VPNv4Route struct {
+	RD      string
+	Label   uint32
+	Prefix  string
+	Nexthop string
+	As      []uint32
+}
+
+// Family implements Route.
+func (r VPNv4Route) Family() Family { return FamilyVPNv4 }
+
+// ASPath implements Route.
+func (r VPNv4Route) ASPath() []uint32 { return r.As }
+
+// EVPNRoute is a decoded L2VPN EVPN NLRI.
+type EVPNRoute struct {
+	// Type is the EVPN route type (e.g. 2 for MAC/IP advertisement).
+	Type int
+	MAC  string
+	IP   string
+	ESI  string
+	As   []uint32
+}
+
+// Family implements Route.
+func (r EVPNRoute) Family() Family { return FamilyEVPN }
+
+// ASPath implements Route.
+func (r EVPNRoute) ASPath() []uint32 { return r.As }
@@ -0,0 +1,93 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/ligato/bgp-agent/bgp"
+	bgp2 "github.com/osrg/gobgp/packet/bgp"
+	"github.com/osrg/gobgp/server"
+	"github.com/osrg/gobgp/table"
+	uuid "github.com/satori/go.uuid"
+)
+
+// AnnouncePath implements bgp.Session by translating route into a table.Path and calling server.AddPath.
+func (s *session) AnnouncePath(route bgp.AnnouncedRoute) (bgp.PathHandle, error) {
+	nlri, err := toIPAddrPrefix(route.Prefix)
+	if err != nil {
+		return nil, err
+	}
+
+	attrs := []bgp2.PathAttributeInterface{
+		bgp2.NewPathAttributeOrigin(route.Origin),
+		bgp2.NewPathAttributeNextHop(route.Nexthop),
+	}
+	if len(route.ASPrepend) > 0 {
+		asParam := bgp2.NewAs4PathParam(bgp2.BGP_ASPATH_ATTR_TYPE_SEQ, route.ASPrepend)
+		attrs = append(attrs, bgp2.NewPathAttributeAsPath([]bgp2.AsPathParamInterface{asParam}))
+	}
+	if len(route.Communities) > 0 {
+		attrs = append(attrs, bgp2.NewPathAttributeCommunities(route.Communities))
+	}
+	if route.MED != nil {
+		attrs = append(attrs, bgp2.NewPathAttributeMultiExitDisc(*route.MED))
+	}
+	if route.LocalPref != nil {
+		attrs = append(attrs, bgp2.NewPathAttributeLocalPref(*route.LocalPref))
+	}
+
+	path := table.NewPath(nil, nlri, false, attrs, time.Now(), false)
+	if _, err := s.server.AddPath("", []*table.Path{path}); err != nil {
+		return nil, err
+	}
+
+	return &announcedPath{server: s.server, path: path, id: bgp.PathUUID(uuid.NewV4().String())}, nil
+}
+
+// toIPAddrPrefix parses a CIDR prefix into the NLRI type expected by table.NewPath for IPv4 unicast
+// routes. IPv6 prefixes are rejected here rather than silently encoded with the IPv4-only NEXT_HOP
+// attribute AnnouncePath attaches below; advertising IPv6 routes needs an MP_REACH_NLRI nexthop
+// instead, which AnnouncePath does not build yet.
+func toIPAddrPrefix(prefix string) (bgp2.AddrPrefixInterface, error) {
+	ip, ipNet, err := net.ParseCIDR(prefix)
+	if err != nil {
+		return nil, fmt.Errorf("invalid prefix %q: %v", prefix, err)
+	}
+	if ip.To4() == nil {
+		return nil, fmt.Errorf("AnnouncePath: IPv6 prefix %q not supported yet", prefix)
+	}
+	ones, _ := ipNet.Mask.Size()
+	return bgp2.NewIPAddrPrefix(uint8(ones), ip.String()), nil
+}
+
+// announcedPath is the gobgp-backed implementation of bgp.PathHandle for a locally-originated path.
+type announcedPath struct {
+	server *server.BgpServer
+	path   *table.Path
+	id     bgp.PathUUID
+}
+
+// UUID implements bgp.PathHandle.
+func (p *announcedPath) UUID() bgp.PathUUID {
+	return p.id
+}
+
+// Withdraw implements bgp.PathHandle.
+func (p *announcedPath) Withdraw() error {
+	return p.server.DeletePath(nil, 0, "", []*table.Path{p.path.Clone(true)})
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"fmt"
+
+	"github.com/ligato/bgp-agent/bgp"
+	bgp2 "github.com/osrg/gobgp/packet/bgp"
+	"github.com/osrg/gobgp/table"
+)
+
+// decodeRoute inspects path.GetRouteFamily() and decodes the path's NLRI and attributes into the matching
+// family-specific bgp.Route. Unsupported families are reported as an error rather than silently dropped.
+func decodeRoute(path *table.Path) (bgp.Route, error) {
+	nexthop := path.GetNexthop()
+	as := asNumbers(path)
+
+	switch path.GetRouteFamily() {
+	case bgp2.RF_IPv4_UC:
+		return bgp.IPv4UnicastRoute{Prefix: path.GetNlri().String(), Nexthop: nexthop, As: as}, nil
+	case bgp2.RF_IPv6_UC:
+		return bgp.IPv6UnicastRoute{Prefix: path.GetNlri().String(), Nexthop: nexthop, As: as}, nil
+	case bgp2.RF_IPv4_VPN:
+		nlri, ok := path.GetNlri().(*bgp2.LabeledVPNIPAddrPrefix)
+		if !ok {
+			return nil, fmt.Errorf("unexpected NLRI type %T for VPNv4 path", path.GetNlri())
+		}
+		var label uint32
+		if len(nlri.Labels.Labels) > 0 {
+			label = nlri.Labels.Labels[0]
+		}
+		return bgp.VPNv4Route{
+			RD:      nlri.RD.String(),
+			Label:   label,
+			Prefix:  fmt.Sprintf("%s/%d", nlri.IPAddr, nlri.Length),
+			Nexthop: nexthop,
+			As:      as,
+		}, nil
+	case bgp2.RF_EVPN:
+		nlri, ok := path.GetNlri().(*bgp2.EVPNNLRI)
+		if !ok {
+			return nil, fmt.Errorf("unexpected NLRI type %T for EVPN path", path.GetNlri())
+		}
+		return decodeEVPN(nlri, as)
+	default:
+		return nil, fmt.Errorf("unsupported route family %v", path.GetRouteFamily())
+	}
+}
+
+// decodeEVPN decodes the fields common to EVPN route types (MAC/IP advertisement, IMET, ...) that this agent
+// knows how to surface.
+func decodeEVPN(nlri *bgp2.EVPNNLRI, as []uint32) (bgp.Route, error) {
+	route := bgp.EVPNRoute{Type: int(nlri.RouteType), As: as}
+	switch r := nlri.RouteTypeData.(type) {
+	case *bgp2.EVPNMacIPAdvertisementRoute:
+		route.MAC = r.MacAddress.String()
+		route.IP = r.IPAddress.String()
+		route.ESI = r.ESI.String()
+	case *bgp2.EVPNIPPrefixRoute:
+		route.IP = r.IPPrefix.String()
+		route.ESI = r.ESI.String()
+	}
+	return route, nil
+}
+
+// asNumbers parses the path's AS-path attribute into a flat list of AS numbers, instead of stringifying it.
+func asNumbers(path *table.Path) []uint32 {
+	asPathAttr := path.GetAsPath()
+	if asPathAttr == nil {
+		return nil
+	}
+	var as []uint32
+	for _, param := range asPathAttr.Value {
+		as = append(as, param.GetAS()...)
+	}
+	return as
+}
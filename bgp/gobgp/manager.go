@@ -0,0 +1,195 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"sync"
+
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/osrg/gobgp/config"
+	bgp2 "github.com/osrg/gobgp/packet/bgp"
+	"github.com/osrg/gobgp/server"
+	"github.com/osrg/gobgp/table"
+	uuid "github.com/satori/go.uuid"
+)
+
+// routerManager is the gobgp-backed implementation of bgp.RouterManager. It
+// is the only place in this package allowed to reference gobgp's
+// server/table types directly; everything above it talks to bgp.Session and
+// bgp.Path.
+type routerManager struct {
+	server *server.BgpServer
+}
+
+// NewRouterManager creates a bgp.RouterManager backed by a fresh gobgp
+// BgpServer instance.
+func NewRouterManager() bgp.RouterManager {
+	return &routerManager{server: server.NewBgpServer()}
+}
+
+// Start implements bgp.RouterManager.
+func (m *routerManager) Start(global *config.Global) (bgp.Session, error) {
+	go m.server.Serve()
+	if err := m.server.Start(global); err != nil {
+		return nil, err
+	}
+	return &session{server: m.server, pathUUIDs: map[string]bgp.PathUUID{}}, nil
+}
+
+// Stop implements bgp.RouterManager.
+func (m *routerManager) Stop() error {
+	return m.server.Stop()
+}
+
+// session is the gobgp-backed implementation of bgp.Session.
+type session struct {
+	server *server.BgpServer
+
+	mu        sync.Mutex
+	pathUUIDs map[string]bgp.PathUUID // keyed by pathKey, stable across an announcement and its withdrawal
+}
+
+// AddNeighbor implements bgp.Session.
+func (s *session) AddNeighbor(neighbor *config.Neighbor) error {
+	return s.server.AddNeighbor(neighbor)
+}
+
+// DeleteNeighbor implements bgp.Session.
+func (s *session) DeleteNeighbor(addr string) error {
+	return s.server.DeleteNeighbor(&config.Neighbor{Config: config.NeighborConfig{NeighborAddress: addr}})
+}
+
+// WatchBestPath implements bgp.Session.
+func (s *session) WatchBestPath(callback func(bgp.Path)) (bgp.Watch, error) {
+	watcher := s.server.Watch(server.WatchBestPath(true))
+	stop := make(chan bool, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case ev := <-watcher.Event():
+				if msg, ok := ev.(*server.WatchEventBestPath); ok {
+					for _, path := range msg.PathList {
+						callback(s.wrap(path))
+					}
+				}
+			}
+		}
+	}()
+	return &watch{watcher: watcher, stop: stop, done: done}, nil
+}
+
+// WatchPeerState implements bgp.Session.
+func (s *session) WatchPeerState(callback func(bgp.PeerEvent)) (bgp.Watch, error) {
+	watcher := s.server.Watch(server.WatchPeerState(true))
+	stop := make(chan bool, 1)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			case ev := <-watcher.Event():
+				if msg, ok := ev.(*server.WatchEventPeerState); ok {
+					callback(bgp.PeerEvent{
+						Neighbor: msg.PeerAddress.String(),
+						State:    peerState(msg.State),
+					})
+				}
+			}
+		}
+	}()
+	return &watch{watcher: watcher, stop: stop, done: done}, nil
+}
+
+// Close implements bgp.Session.
+func (s *session) Close() error {
+	return nil
+}
+
+// peerState maps a gobgp FSM state to the router-agnostic bgp.PeerState.
+func peerState(state bgp2.FSMState) bgp.PeerState {
+	switch state {
+	case bgp2.BGP_FSM_ESTABLISHED:
+		return bgp.PeerStateEstablished
+	case bgp2.BGP_FSM_ACTIVE:
+		return bgp.PeerStateActive
+	default:
+		return bgp.PeerStateIdle
+	}
+}
+
+// wrap assigns (or recalls) the stable bgp.PathUUID for a gobgp table.Path
+// and returns the bgp.Path view handed to subscribers.
+func (s *session) wrap(path *table.Path) bgp.Path {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := pathKey(path)
+	id, known := s.pathUUIDs[key]
+	if !known {
+		id = bgp.PathUUID(uuid.NewV4().String())
+		s.pathUUIDs[key] = id
+	}
+	if path.IsWithdraw() {
+		delete(s.pathUUIDs, key)
+	}
+	return &gobgpPath{path: path, id: id}
+}
+
+// pathKey derives the identity used to correlate an announcement with its
+// later withdrawal, since gobgp does not expose a stable UUID of its own.
+func pathKey(path *table.Path) string {
+	return path.GetNlri().String()
+}
+
+// watch is the gobgp-backed implementation of bgp.Watch.
+type watch struct {
+	watcher *server.Watcher
+	stop    chan bool
+	done    chan struct{}
+}
+
+// Stop implements bgp.Watch.
+func (w *watch) Stop() {
+	close(w.stop)
+	<-w.done
+	w.watcher.Stop()
+}
+
+// gobgpPath adapts a gobgp table.Path to bgp.Path.
+type gobgpPath struct {
+	path *table.Path
+	id   bgp.PathUUID
+}
+
+// UUID implements bgp.Path.
+func (p *gobgpPath) UUID() bgp.PathUUID {
+	return p.id
+}
+
+// IsWithdraw implements bgp.Path.
+func (p *gobgpPath) IsWithdraw() bool {
+	return p.path.IsWithdraw()
+}
+
+// Route implements bgp.Path.
+func (p *gobgpPath) Route() (bgp.Route, error) {
+	return decodeRoute(p.path)
+}
@@ -12,154 +12,598 @@
 // See the License for the specific language governing permissions and
 // limitations under the License.
 
-//Package gobgp contains Ligato GoBGP BGP Plugin implementation
+// Package gobgp contains Ligato GoBGP BGP Plugin implementation
 package gobgp
 
 import (
+	"errors"
 	"fmt"
+	"sync"
+
 	"github.com/ligato/bgp-agent/bgp"
 	"github.com/ligato/cn-infra/flavors/local"
 	"github.com/osrg/gobgp/config"
-	"github.com/osrg/gobgp/server"
-	"strconv"
-	"sync"
 )
 
+// errSessionNotStarted is returned by AddNeighbor, DeleteNeighbor and AnnouncePath when they are
+// called before the reconciler has converged any configuration into a running session yet.
+var errSessionNotStarted = errors.New("gobgp: session not started yet; inject SessionConfig or call OnConfigurationChange first")
+
 // Plugin is GoBGP Ligato BGP Plugin implementation
 type Plugin struct {
 	Deps
-	server               *server.BgpServer
-	serverWatcher        *server.Watcher
-	subscribersCallbacks map[Subscriber]func(*bgp.ReachableIPRoute)
-	stopWatch            chan bool
-	watchWG              sync.WaitGroup // wait group that allows to wait until Watch loop is ended
+	speaker    bgp.Speaker
+	routeWatch bgp.Watch
+	peerWatch  bgp.Watch
+
+	sessionMu sync.RWMutex
+	session   bgp.Session // set once startSession has run; guarded separately from configMu so
+	// AddNeighbor/DeleteNeighbor/AnnouncePath can read it without taking the reconciler's lock
+
+	callbacksMu          sync.RWMutex
+	subscribersCallbacks map[Subscriber]func(*bgp.RouteEvent)
+	peerCallbacks        map[Subscriber]func(*bgp.PeerEvent)
+	reconcileCallbacks   map[Subscriber]func(*bgp.ReconcileEvent)
+
+	neighborsMu sync.RWMutex
+	neighbors   map[string]*config.Neighbor // neighbors added so far, keyed by address, for ListNeighbors()
+
+	routesMu sync.Mutex
+	routes   map[bgp.PathUUID]bgp.Route // last known state of every currently reachable route, for Snapshot()
+
+	requestedFamiliesMu sync.Mutex
+	requestedFamilies   map[bgp.Family]bool // families requested via WatchRoutes, used to enable the matching AfiSafi before the session starts
+
+	configMu        sync.Mutex
+	currentConfig   *config.Bgp      // last configuration successfully applied by reconcile; nil until the session is started
+	desiredConfigCh chan *config.Bgp // configurations submitted via OnConfigurationChange, consumed by reconcileLoop
 }
 
 // Deps combines all needed dependencies for Plugin struct. These dependencies should be injected into Plugin by using constructor's Deps parameter.
 type Deps struct {
-	local.PluginInfraDeps             // inject
-	SessionConfig         *config.Bgp // inject
-	PluginName            string      // inject
+	local.PluginInfraDeps                // inject
+	SessionConfig         *config.Bgp    // inject, optional; can instead be supplied later via OnConfigurationChange
+	SpeakerConfig         *SpeakerConfig // inject, optional; defaults to SpeakerModeInProcess
+	PluginName            string         // inject
 }
 
 // Subscriber is by-name identification of registered watcher
 type Subscriber string
 
-//New creates a GoBGP Ligato BGP Plugin implementation. Needed dependencies are injected into plugin implementation.
+// New creates a GoBGP Ligato BGP Plugin implementation. Needed dependencies are injected into plugin implementation.
 func New(dependencies Deps) *Plugin {
-	return &Plugin{Deps: dependencies, subscribersCallbacks: map[Subscriber]func(*bgp.ReachableIPRoute){}}
+	return &Plugin{
+		Deps:                 dependencies,
+		subscribersCallbacks: map[Subscriber]func(*bgp.RouteEvent){},
+		peerCallbacks:        map[Subscriber]func(*bgp.PeerEvent){},
+		reconcileCallbacks:   map[Subscriber]func(*bgp.ReconcileEvent){},
+		neighbors:            map[string]*config.Neighbor{},
+		routes:               map[bgp.PathUUID]bgp.Route{},
+		requestedFamilies:    map[bgp.Family]bool{},
+		desiredConfigCh:      make(chan *config.Bgp, 1),
+	}
 }
 
-//Init creates the gobgp server and checks if needed SessionConfig was injected and fails if it is not.
+// Init creates the bgp.Speaker selected by SpeakerConfig (the in-process gobgp driver by default, or a
+// bgp-speaker child process when SpeakerConfig.Mode is SpeakerModeExec). SessionConfig no longer needs
+// to be injected up front: it can instead arrive later through OnConfigurationChange, which is how a
+// file-watch-driven config loader would deliver it once the watched file appears.
 func (plugin *Plugin) Init() error {
 	plugin.Log.Debug("Init goBgp plugin")
-	if plugin.SessionConfig == nil {
-		//TODO add config load in case of missing config injection
-		return fmt.Errorf("Can't init GoBGP plugin without configuration")
-	}
-	plugin.server = server.NewBgpServer()
+	plugin.speaker = plugin.newSpeaker()
 
 	return nil
 }
 
-// AfterInit starts gobgp with dedicated goroutine for watching gobgp and forwarding best path reachable ip routes to registered watchers.
-// After start of gobgp session, known neighbors from configuration are added to gobgp server.
+// AfterInit starts the reconciler goroutine that applies every configuration submitted through
+// OnConfigurationChange, then, if SessionConfig was injected, submits it as the first desired
+// configuration. The BGP session itself is brought up by the reconciler rather than by AfterInit
+// directly, so that the initial startup and any later configuration change converge through the same
+// code path and AfterInit can return before the session is actually up.
 // Due to fact that AfterInit is called once Init() of all plugins have returned without error, other plugins can be registered watchers
 // from the start of gobgp server if they call this plugin's WatchIPRoutes() in their Init(). In this way they won't miss any information
 // forwarded to registered watchers just because they registered too late.
 func (plugin *Plugin) AfterInit() error {
-	go plugin.server.Serve()
-	if err := plugin.startSession(); err != nil {
+	go plugin.reconcileLoop()
+
+	if plugin.SessionConfig != nil {
+		return plugin.OnConfigurationChange(plugin.SessionConfig)
+	}
+	return nil
+}
+
+// OnConfigurationChange submits newConfig as the desired SessionConfig. The reconciler goroutine
+// started by AfterInit picks it up and converges towards it: starting the session if it is not
+// running yet, or otherwise diffing newConfig.Neighbors against the last applied configuration and
+// applying only the AddNeighbor/DeleteNeighbor calls needed to converge, without tearing down
+// established peerings unaffected by the change. Only the newest submitted configuration is kept if
+// several arrive before the reconciler catches up. Convergence itself happens asynchronously; register
+// a WatchReconcileEvents callback to observe its outcome.
+func (plugin *Plugin) OnConfigurationChange(newConfig *config.Bgp) error {
+	if newConfig == nil {
+		return fmt.Errorf("OnConfigurationChange: configuration must not be nil")
+	}
+	select {
+	case plugin.desiredConfigCh <- newConfig:
+	default:
+		select {
+		case <-plugin.desiredConfigCh:
+		default:
+		}
+		plugin.desiredConfigCh <- newConfig
+	}
+	return nil
+}
+
+// reconcileLoop applies every desired configuration submitted through OnConfigurationChange, one at a
+// time, until Close shuts it down.
+func (plugin *Plugin) reconcileLoop() {
+	for desired := range plugin.desiredConfigCh {
+		plugin.reconcile(desired)
+	}
+}
+
+// reconcile converges the running session towards desired: it starts the session on the first call,
+// and on every later call diffs desired.Neighbors against the last applied configuration, applying
+// only the AddNeighbor/DeleteNeighbor calls needed to converge. Fields outside Neighbors (e.g. Global)
+// cannot be changed on an already-started gobgp session, so they are only honored on the first call;
+// this is what keeps the reconciler from tearing down established sessions over unrelated changes.
+func (plugin *Plugin) reconcile(desired *config.Bgp) {
+	plugin.configMu.Lock()
+	defer plugin.configMu.Unlock()
+
+	if plugin.currentConfig == nil {
+		if err := plugin.startSession(desired); err != nil {
+			plugin.Log.Error("Failed to initialize go server from reconciled configuration", plugin.PluginName, err)
+			plugin.emitReconcileEvent(&bgp.ReconcileEvent{Op: bgp.ReconcileAdded, Err: err})
+			return
+		}
+		plugin.currentConfig = desired
+		return
+	}
+
+	previous := neighborsByAddress(plugin.currentConfig.Neighbors)
+	for i := range desired.Neighbors {
+		cfg := &desired.Neighbors[i]
+		addr := cfg.Config.NeighborAddress
+		old, known := previous[addr]
+		delete(previous, addr)
+
+		switch {
+		case !known:
+			_, err := plugin.AddNeighbor(cfg)
+			plugin.emitReconcileEvent(&bgp.ReconcileEvent{Neighbor: addr, Op: bgp.ReconcileAdded, Err: err})
+		case neighborSessionFieldsChanged(old, cfg):
+			err := plugin.updateNeighbor(cfg)
+			plugin.emitReconcileEvent(&bgp.ReconcileEvent{Neighbor: addr, Op: bgp.ReconcileUpdated, Err: err})
+		}
+	}
+	for addr := range previous {
+		err := plugin.DeleteNeighbor(addr)
+		plugin.emitReconcileEvent(&bgp.ReconcileEvent{Neighbor: addr, Op: bgp.ReconcileDeleted, Err: err})
+	}
+
+	plugin.currentConfig = desired
+}
+
+// startSession brings up plugin.speaker with desired, adds desired's neighbors and registers the
+// best-path and peer-state watchers. It is called once, the first time reconcile runs. On any
+// failure it rolls back plugin.session and stops whatever it managed to start, so a partially
+// started speaker is never left behind for reconcile to mistake for a converged session and for
+// Close to leak.
+func (plugin *Plugin) startSession(desired *config.Bgp) (err error) {
+	plugin.enableRequestedAfiSafis(desired)
+
+	session, err := plugin.speaker.Start(&desired.Global)
+	if err != nil {
+		plugin.Log.Error("Failed to initialize go server", plugin.PluginName, err)
+		return err
+	}
+	plugin.sessionMu.Lock()
+	plugin.session = session
+	plugin.sessionMu.Unlock()
+
+	var addedAddrs []string
+	defer func() {
+		if err == nil {
+			return
+		}
+		if plugin.routeWatch != nil {
+			plugin.routeWatch.Stop()
+			plugin.routeWatch = nil
+		}
+		if plugin.peerWatch != nil {
+			plugin.peerWatch.Stop()
+			plugin.peerWatch = nil
+		}
+		plugin.neighborsMu.Lock()
+		for _, addr := range addedAddrs {
+			delete(plugin.neighbors, addr)
+		}
+		plugin.neighborsMu.Unlock()
+		session.Close()
+		plugin.speaker.Stop()
+		plugin.sessionMu.Lock()
+		plugin.session = nil
+		plugin.sessionMu.Unlock()
+	}()
+
+	for i := range desired.Neighbors {
+		if _, err = plugin.AddNeighbor(&desired.Neighbors[i]); err != nil {
+			return err
+		}
+		addedAddrs = append(addedAddrs, desired.Neighbors[i].Config.NeighborAddress)
+	}
+
+	routeWatch, err := session.WatchBestPath(plugin.onBestPath)
+	if err != nil {
 		return err
 	}
-	if err := plugin.addKnownNeighbors(); err != nil {
+	plugin.routeWatch = routeWatch
+
+	peerWatch, err := session.WatchPeerState(plugin.onPeerState)
+	if err != nil {
 		return err
 	}
-	plugin.stopWatch = make(chan bool, 1)
-	plugin.serverWatcher = plugin.server.Watch(server.WatchBestPath(true))
-	plugin.watchWG.Add(1)
-	go plugin.watchChanges(plugin.serverWatcher)
+	plugin.peerWatch = peerWatch
 
 	return nil
 }
 
-// watchChanges watches for events from goBGP server, translates them to bgp.ReachableIPRoute and sends them to registered watchers.
-func (plugin *Plugin) watchChanges(watcher *server.Watcher) {
-	defer plugin.watchWG.Done()
+// neighborSessionFieldsChanged reports whether any of the neighbor fields that require deleting and
+// re-adding the neighbor to take effect differ between old and cfg. Unrelated field changes (e.g.
+// fields reconcile doesn't otherwise touch) must not tear down an established peering.
+func neighborSessionFieldsChanged(old, cfg *config.Neighbor) bool {
+	return old.Config.PeerAs != cfg.Config.PeerAs ||
+		old.Config.LocalAs != cfg.Config.LocalAs ||
+		old.Config.AuthPassword != cfg.Config.AuthPassword ||
+		old.Transport.Config.LocalAddress != cfg.Transport.Config.LocalAddress ||
+		old.Transport.Config.PassiveMode != cfg.Transport.Config.PassiveMode
+}
 
-	for {
-		select {
-		case <-plugin.stopWatch:
-			plugin.Log.Debug("Stop Watching ", plugin.PluginName)
-			return
-		case ev := <-watcher.Event():
-			switch msg := ev.(type) {
-			case *server.WatchEventBestPath:
-				for _, path := range msg.PathList {
-					asPath := path.GetAsPath().String()
-					as, err := strconv.ParseUint(asPath, 10, 32)
-					if err != nil {
-						plugin.Log.Warnf("Ignoring Path '%s' due to parse error: %v", asPath, err)
-						continue
-					}
-					pathInfo := bgp.ReachableIPRoute{
-						As:      uint32(as),
-						Prefix:  path.GetNlri().String(),
-						Nexthop: path.GetNexthop(),
-					}
-					plugin.Log.Debug("Fill channel with new path", pathInfo)
-					for _, callback := range plugin.subscribersCallbacks {
-						callback(&pathInfo)
-					}
-				}
-			}
-		}
+// updateNeighbor re-applies a neighbor whose configuration changed, by deleting and re-adding it: the
+// Session interface has no in-place update, so this is the reconciler's equivalent of an
+// UpdateNeighbor step.
+func (plugin *Plugin) updateNeighbor(cfg *config.Neighbor) error {
+	addr := cfg.Config.NeighborAddress
+	if err := plugin.DeleteNeighbor(addr); err != nil {
+		return err
+	}
+	_, err := plugin.AddNeighbor(cfg)
+	return err
+}
+
+// neighborsByAddress indexes neighbors by NeighborAddress for reconcile's diff.
+func neighborsByAddress(neighbors []config.Neighbor) map[string]*config.Neighbor {
+	byAddress := make(map[string]*config.Neighbor, len(neighbors))
+	for i := range neighbors {
+		byAddress[neighbors[i].Config.NeighborAddress] = &neighbors[i]
+	}
+	return byAddress
+}
+
+// onBestPath decodes a bgp.Path best-path event into a family-specific bgp.Route, keeps plugin.routes in sync so
+// that Snapshot() reflects the currently reachable routes, and sends the resulting bgp.RouteEvent to registered
+// watchers.
+func (plugin *Plugin) onBestPath(path bgp.Path) {
+	route, err := path.Route()
+	if err != nil {
+		plugin.Log.Warnf("Ignoring Path '%s' due to decode error: %v", path.UUID(), err)
+		return
+	}
+	kind := bgp.Added
+	if path.IsWithdraw() {
+		kind = bgp.Withdrawn
+	}
+
+	plugin.routesMu.Lock()
+	if kind == bgp.Added {
+		plugin.routes[path.UUID()] = route
+	} else {
+		delete(plugin.routes, path.UUID())
+	}
+	plugin.routesMu.Unlock()
+
+	event := bgp.RouteEvent{Kind: kind, Route: route, PathUUID: path.UUID()}
+	plugin.Log.Debug("Fill channel with new route event", event)
+	plugin.callbacksMu.RLock()
+	defer plugin.callbacksMu.RUnlock()
+	for _, callback := range plugin.subscribersCallbacks {
+		callback(&event)
+	}
+}
+
+// onPeerState translates a bgp.PeerEvent neighbor state transition and sends it to registered watchers.
+func (plugin *Plugin) onPeerState(event bgp.PeerEvent) {
+	plugin.Log.Debug("Fill channel with new peer event", event)
+	plugin.callbacksMu.RLock()
+	defer plugin.callbacksMu.RUnlock()
+	for _, callback := range plugin.peerCallbacks {
+		callback(&event)
 	}
 }
 
-//Close stops dedicated goroutine for watching gobgp. Then stops watcher provider by gobgp server and finally stops that gobgp server itself.
+// Close stops the reconciler goroutine and, if a session was ever started, stops the best-path and
+// peer-state watchers, closes the session and finally stops the underlying bgp.Speaker. It takes
+// configMu, the same lock reconcile holds for the whole of its call including startSession, so Close
+// cannot race a startSession that is concurrently deciding whether to roll plugin.session back - it
+// either runs before that startSession starts or waits until it (and any rollback) is done. It checks
+// plugin.session rather than plugin.currentConfig, since startSession can bring up a session (and
+// watchers) that reconcile never gets to record as converged - that session must still be torn down.
+// No further OnConfigurationChange calls are permitted once Close has been called.
 func (plugin *Plugin) Close() error {
 	plugin.Log.Info("Closing goBgp plugin ", plugin.PluginName)
-	close(plugin.stopWatch) //command to stop watching
-	plugin.watchWG.Wait()   //wait for actual stop of watching
-	plugin.serverWatcher.Stop()
-	return plugin.server.Stop()
+	close(plugin.desiredConfigCh)
+
+	plugin.configMu.Lock()
+	defer plugin.configMu.Unlock()
+
+	session, err := plugin.activeSession()
+	if err != nil {
+		return nil
+	}
+
+	if plugin.routeWatch != nil {
+		plugin.routeWatch.Stop()
+	}
+	if plugin.peerWatch != nil {
+		plugin.peerWatch.Stop()
+	}
+	if err := session.Close(); err != nil {
+		return err
+	}
+	return plugin.speaker.Stop()
 }
 
-//WatchIPRoutes subscribes consumer to notifications for any new learned IP-based routes.
-//Subscription is not retroactive, that means that any IP-based routes learned in the past are not send to new subscribers.
-//This also means that if you want be notified of all learned IP-based routes, you must subscribe before calling of
-//AfterInit(). In case of external(=not other plugin started with this plugin) subscribers this means before plugin start.
-//However, late subscribers are permitted (no error will be returned), but they can miss some learned IP-based routes.
+// WatchIPRoutes subscribes consumer to notifications for any new learned IPv4 unicast routes.
+// It is a compatibility wrapper around WatchRoutes(subscriber, []bgp.Family{bgp.FamilyIPv4Unicast}, ...) that only
+// forwards bgp.Added events, so existing subscribers that only know about IPv4 unicast do not need to change.
+// Subscription is not retroactive, that means that any IP-based routes learned in the past are not send to new subscribers.
+// This also means that if you want be notified of all learned IP-based routes, you must subscribe before calling of
+// AfterInit(). In case of external(=not other plugin started with this plugin) subscribers this means before plugin start.
+// However, late subscribers are permitted (no error will be returned), but they can miss some learned IP-based routes -
+// use Snapshot() to resync.
 func (plugin *Plugin) WatchIPRoutes(subscriber Subscriber, callback func(*bgp.ReachableIPRoute)) (Subscription, error) {
-	plugin.Log.Infof("Subscriber %s registering for watching of IPRoutes in %s.", subscriber, plugin.PluginName)
-	plugin.subscribersCallbacks[subscriber] = callback
+	return plugin.WatchRoutes(subscriber, []bgp.Family{bgp.FamilyIPv4Unicast}, func(event *bgp.RouteEvent) {
+		if event.Kind != bgp.Added {
+			return
+		}
+		route, ok := event.Route.(bgp.IPv4UnicastRoute)
+		if !ok {
+			return
+		}
+		var as uint32
+		if len(route.As) > 0 {
+			as = route.As[0]
+		}
+		callback(&bgp.ReachableIPRoute{PathUUID: event.PathUUID, As: as, Prefix: route.Prefix, Nexthop: route.Nexthop})
+	})
+}
+
+// WatchRoutes subscribes consumer to notifications about route reachability changes, both new best-path additions
+// and withdrawals of previously announced routes, restricted to the given families (or all families, if none are
+// given). The requested families are enabled on the gobgp session's AfiSafi config the next time AfterInit runs.
+// See WatchIPRoutes for subscription timing caveats.
+func (plugin *Plugin) WatchRoutes(subscriber Subscriber, families []bgp.Family, callback func(*bgp.RouteEvent)) (Subscription, error) {
+	plugin.Log.Infof("Subscriber %s registering for watching of Routes (families=%v) in %s.", subscriber, families, plugin.PluginName)
+
+	plugin.requestedFamiliesMu.Lock()
+	for _, family := range families {
+		plugin.requestedFamilies[family] = true
+	}
+	plugin.requestedFamiliesMu.Unlock()
+
+	filtered := callback
+	if len(families) > 0 {
+		allowed := make(map[bgp.Family]bool, len(families))
+		for _, family := range families {
+			allowed[family] = true
+		}
+		filtered = func(event *bgp.RouteEvent) {
+			if !allowed[event.Route.Family()] {
+				return
+			}
+			callback(event)
+		}
+	}
+
+	plugin.callbacksMu.Lock()
+	plugin.subscribersCallbacks[subscriber] = filtered
+	plugin.callbacksMu.Unlock()
 	return &subscription{subscriber: subscriber, plugin: plugin}, nil
 }
 
-//startSession starts session on already running goBGP server
-func (plugin *Plugin) startSession() error {
-	if err := plugin.server.Start(&plugin.SessionConfig.Global); err != nil {
-		plugin.Log.Error("Failed to initialize go server", plugin.PluginName, err)
-		return err
+// WatchPeerState subscribes consumer to notifications about neighbor FSM state transitions (Idle/Active/Established).
+// See WatchIPRoutes for subscription timing caveats.
+func (plugin *Plugin) WatchPeerState(subscriber Subscriber, callback func(*bgp.PeerEvent)) (Subscription, error) {
+	plugin.Log.Infof("Subscriber %s registering for watching of PeerState in %s.", subscriber, plugin.PluginName)
+	plugin.callbacksMu.Lock()
+	plugin.peerCallbacks[subscriber] = callback
+	plugin.callbacksMu.Unlock()
+	return &peerSubscription{subscriber: subscriber, plugin: plugin}, nil
+}
+
+// WatchReconcileEvents subscribes consumer to notifications about configuration reconciliation: one
+// bgp.ReconcileEvent per neighbor the reconciler added, updated or deleted while converging towards a
+// configuration submitted through OnConfigurationChange, including any error encountered applying it.
+func (plugin *Plugin) WatchReconcileEvents(subscriber Subscriber, callback func(*bgp.ReconcileEvent)) (Subscription, error) {
+	plugin.Log.Infof("Subscriber %s registering for watching of ReconcileEvents in %s.", subscriber, plugin.PluginName)
+	plugin.callbacksMu.Lock()
+	plugin.reconcileCallbacks[subscriber] = callback
+	plugin.callbacksMu.Unlock()
+	return &reconcileSubscription{subscriber: subscriber, plugin: plugin}, nil
+}
+
+// emitReconcileEvent sends event to every watcher registered via WatchReconcileEvents.
+func (plugin *Plugin) emitReconcileEvent(event *bgp.ReconcileEvent) {
+	plugin.callbacksMu.RLock()
+	defer plugin.callbacksMu.RUnlock()
+	for _, callback := range plugin.reconcileCallbacks {
+		callback(event)
 	}
-	return nil
 }
 
-// addKnownNeighbors configures goBGP server for known neighbors from config
-func (plugin *Plugin) addKnownNeighbors() error {
-	for _, neighbor := range plugin.SessionConfig.Neighbors {
-		if err := plugin.server.AddNeighbor(&neighbor); err != nil {
-			plugin.Log.Error("Failed to add go neighbour", plugin.PluginName, err)
-			return err
+// Snapshot returns the set of routes currently known to be reachable, across all families. It lets a subscriber
+// that registers after a route was announced resync without waiting for the next best-path update.
+func (plugin *Plugin) Snapshot() []bgp.Route {
+	plugin.routesMu.Lock()
+	defer plugin.routesMu.Unlock()
+	routes := make([]bgp.Route, 0, len(plugin.routes))
+	for _, route := range plugin.routes {
+		routes = append(routes, route)
+	}
+	return routes
+}
+
+// enableRequestedAfiSafis makes sure every family requested so far via WatchRoutes is enabled on
+// desired.Global.AfiSafis, in addition to whatever is already present there, before the session starts.
+func (plugin *Plugin) enableRequestedAfiSafis(desired *config.Bgp) {
+	plugin.requestedFamiliesMu.Lock()
+	families := make([]bgp.Family, 0, len(plugin.requestedFamilies))
+	for family := range plugin.requestedFamilies {
+		families = append(families, family)
+	}
+	plugin.requestedFamiliesMu.Unlock()
+
+	for _, family := range families {
+		name := afiSafiName(family)
+		if name == "" || hasAfiSafi(desired.Global.AfiSafis, name) {
+			continue
+		}
+		desired.Global.AfiSafis = append(desired.Global.AfiSafis, config.AfiSafi{
+			Config: config.AfiSafiConfig{AfiSafiName: config.AfiSafiType(name), Enabled: true},
+		})
+	}
+}
+
+// afiSafiName maps a bgp.Family to the AfiSafiName used in gobgp's config.AfiSafi.
+func afiSafiName(family bgp.Family) string {
+	switch family {
+	case bgp.FamilyIPv4Unicast:
+		return "ipv4-unicast"
+	case bgp.FamilyIPv6Unicast:
+		return "ipv6-unicast"
+	case bgp.FamilyVPNv4:
+		return "l3vpn-ipv4-unicast"
+	case bgp.FamilyEVPN:
+		return "l2vpn-evpn"
+	default:
+		return ""
+	}
+}
+
+// hasAfiSafi reports whether name is already present among afiSafis.
+func hasAfiSafi(afiSafis []config.AfiSafi, name string) bool {
+	for _, afiSafi := range afiSafis {
+		if string(afiSafi.Config.AfiSafiName) == name {
+			return true
 		}
 	}
+	return false
+}
+
+// activeSession returns the currently running bgp.Session, guarded by sessionMu so callers never
+// observe startSession's write to plugin.session half-done. It errors with errSessionNotStarted
+// if the reconciler has not converged any configuration into a running session yet - e.g. when
+// SessionConfig was not injected and OnConfigurationChange has not been called yet.
+func (plugin *Plugin) activeSession() (bgp.Session, error) {
+	plugin.sessionMu.RLock()
+	defer plugin.sessionMu.RUnlock()
+	if plugin.session == nil {
+		return nil, errSessionNotStarted
+	}
+	return plugin.session, nil
+}
+
+// AddNeighbor configures a new BGP neighbor on the running session. It is safe to call any time after AfterInit,
+// including at runtime once neighbors are learned from orchestration rather than static config, but it errors with
+// errSessionNotStarted if called before the reconciler has started a session.
+func (plugin *Plugin) AddNeighbor(cfg *config.Neighbor) (NeighborHandle, error) {
+	session, err := plugin.activeSession()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.AddNeighbor(cfg); err != nil {
+		plugin.Log.Error("Failed to add go neighbour", plugin.PluginName, err)
+		return nil, err
+	}
+	addr := cfg.Config.NeighborAddress
+	plugin.neighborsMu.Lock()
+	plugin.neighbors[addr] = cfg
+	plugin.neighborsMu.Unlock()
+	return &neighborHandle{addr: addr, plugin: plugin}, nil
+}
 
+// DeleteNeighbor removes a previously configured neighbor from the running session. It is safe to call any time
+// after AfterInit, but it errors with errSessionNotStarted if called before the reconciler has started a session.
+func (plugin *Plugin) DeleteNeighbor(addr string) error {
+	session, err := plugin.activeSession()
+	if err != nil {
+		return err
+	}
+	if err := session.DeleteNeighbor(addr); err != nil {
+		plugin.Log.Error("Failed to delete go neighbour", plugin.PluginName, err)
+		return err
+	}
+	plugin.neighborsMu.Lock()
+	delete(plugin.neighbors, addr)
+	plugin.neighborsMu.Unlock()
 	return nil
 }
 
+// ListNeighbors returns the neighbors currently configured on the running session.
+func (plugin *Plugin) ListNeighbors() []NeighborInfo {
+	plugin.neighborsMu.RLock()
+	defer plugin.neighborsMu.RUnlock()
+	infos := make([]NeighborInfo, 0, len(plugin.neighbors))
+	for addr := range plugin.neighbors {
+		infos = append(infos, NeighborInfo{Address: addr})
+	}
+	return infos
+}
+
+// AnnouncePath advertises a locally-originated prefix to peers. The returned bgp.PathHandle can be used to withdraw
+// it later without reconstructing the path. It errors with errSessionNotStarted if called before the reconciler has
+// started a session.
+func (plugin *Plugin) AnnouncePath(route bgp.AnnouncedRoute) (bgp.PathHandle, error) {
+	session, err := plugin.activeSession()
+	if err != nil {
+		return nil, err
+	}
+	handle, err := session.AnnouncePath(route)
+	if err != nil {
+		plugin.Log.Error("Failed to announce path", plugin.PluginName, err)
+		return nil, err
+	}
+	return handle, nil
+}
+
+// NeighborInfo is a read-only snapshot of a neighbor configured via AddNeighbor or SessionConfig.Neighbors.
+type NeighborInfo struct {
+	Address string
+}
+
+// NeighborHandle represents a neighbor added at runtime via Plugin.AddNeighbor. Closing it removes the neighbor
+// from the running session. There is intentionally no separate per-neighbor event stream: bgp.PeerEvent already
+// carries the neighbor address, so WatchPeerState is sufficient to observe this neighbor's state transitions.
+type NeighborHandle interface {
+	// Close removes the neighbor from the running session.
+	Close() error
+}
+
+// neighborHandle is Plugin's NeighborHandle implementation returned by AddNeighbor.
+type neighborHandle struct {
+	addr   string
+	plugin *Plugin
+}
+
+// Close removes the neighbor from the running session.
+func (h *neighborHandle) Close() error {
+	return h.plugin.DeleteNeighbor(h.addr)
+}
+
 // Subscription represents both-side-agreed agreement between Plugin and subscribers that binds Plugin to notify subscribers
-// about new learned IP-based routes.
+// about route or peer events.
 // Subscription implementation is meant for subscriber side as evidence about agreement and way how to access subscriber side
 // control upon agreement (i.e. to close it). Implementations could be not thread-safe.
 type Subscription interface {
@@ -167,14 +611,44 @@ type Subscription interface {
 	Close() error
 }
 
-// subscription is Plugin's simple Subscription implementation that is sent to watchers
-type subscription struct{
+// subscription is Plugin's Subscription implementation sent to route watchers
+type subscription struct {
 	subscriber Subscriber
-	plugin *Plugin
+	plugin     *Plugin
 }
 
-//Close ends the agreement between Plugin and subscriber. Plugin stops sending watcher any further notifications.
+// Close ends the agreement between Plugin and subscriber. Plugin stops sending watcher any further notifications.
 func (s *subscription) Close() error {
+	s.plugin.callbacksMu.Lock()
+	defer s.plugin.callbacksMu.Unlock()
 	delete(s.plugin.subscribersCallbacks, s.subscriber)
 	return nil
 }
+
+// peerSubscription is Plugin's Subscription implementation sent to peer-state watchers
+type peerSubscription struct {
+	subscriber Subscriber
+	plugin     *Plugin
+}
+
+// Close ends the agreement between Plugin and subscriber. Plugin stops sending watcher any further notifications.
+func (s *peerSubscription) Close() error {
+	s.plugin.callbacksMu.Lock()
+	defer s.plugin.callbacksMu.Unlock()
+	delete(s.plugin.peerCallbacks, s.subscriber)
+	return nil
+}
+
+// reconcileSubscription is Plugin's Subscription implementation sent to reconcile-event watchers
+type reconcileSubscription struct {
+	subscriber Subscriber
+	plugin     *Plugin
+}
+
+// Close ends the agreement between Plugin and subscriber. Plugin stops sending watcher any further notifications.
+func (s *reconcileSubscription) Close() error {
+	s.plugin.callbacksMu.Lock()
+	defer s.plugin.callbacksMu.Unlock()
+	delete(s.plugin.reconcileCallbacks, s.subscriber)
+	return nil
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gobgp
+
+import (
+	"github.com/ligato/bgp-agent/bgp"
+	"github.com/ligato/bgp-agent/bgp/execbgp"
+)
+
+// SpeakerMode selects which bgp.Speaker implementation backs the plugin.
+type SpeakerMode int
+
+const (
+	// SpeakerModeInProcess runs gobgp linked into the agent's own address space. This is the default.
+	SpeakerModeInProcess SpeakerMode = iota
+	// SpeakerModeExec runs gobgp as a separate "bgp-speaker" child process reached over RPC (bgp/execbgp).
+	SpeakerModeExec
+)
+
+// SpeakerConfig selects and configures the bgp.Speaker backing the plugin.
+type SpeakerConfig struct {
+	// Mode is SpeakerModeInProcess (default) or SpeakerModeExec.
+	Mode SpeakerMode
+	// ExecPath is the path to the bgp-speaker binary. Required when Mode is SpeakerModeExec.
+	ExecPath string
+}
+
+// newSpeaker builds the bgp.Speaker selected by plugin.SpeakerConfig, defaulting to the in-process
+// gobgp driver when no SpeakerConfig was injected.
+func (plugin *Plugin) newSpeaker() bgp.Speaker {
+	if plugin.SpeakerConfig != nil && plugin.SpeakerConfig.Mode == SpeakerModeExec {
+		return execbgp.NewSpeaker(plugin.SpeakerConfig.ExecPath)
+	}
+	return NewRouterManager()
+}
@@ -0,0 +1,98 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bgp defines a router-agnostic abstraction over a BGP speaker.
+// Concrete drivers (e.g. bgp/gobgp) implement RouterManager, Session and
+// Path so that gobgp.Plugin and its callers never depend on a specific BGP
+// implementation directly. This mirrors the decoupling between control
+// plane and dataplane driver found in other router-agnostic BGP managers
+// and is what allows a second driver (another BGP daemon, or a mock used in
+// tests) to be added without touching Plugin, watchChanges or
+// WatchIPRoutes callers.
+package bgp
+
+import "github.com/osrg/gobgp/config"
+
+// RouterManager abstracts bringing up and tearing down a BGP speaker.
+// Implementations own whatever process or library is actually speaking BGP;
+// the gobgp driver in bgp/gobgp is the first one.
+type RouterManager interface {
+	// Start brings up the BGP speaker with the given global config and
+	// returns the resulting Session.
+	Start(global *config.Global) (Session, error)
+	// Stop tears down the BGP speaker and releases all resources it holds.
+	Stop() error
+}
+
+// Session represents a running BGP speaker instance returned by
+// RouterManager.Start.
+type Session interface {
+	// AddNeighbor configures a new BGP neighbor on the running session.
+	AddNeighbor(neighbor *config.Neighbor) error
+	// DeleteNeighbor removes a previously configured neighbor.
+	DeleteNeighbor(addr string) error
+	// WatchBestPath registers callback to be called for every best-path
+	// event (announcement or withdrawal) observed by the speaker.
+	WatchBestPath(callback func(Path)) (Watch, error)
+	// WatchPeerState registers callback to be called for every neighbor FSM
+	// state transition (e.g. Idle/Active/Established) observed by the
+	// speaker.
+	WatchPeerState(callback func(PeerEvent)) (Watch, error)
+	// AnnouncePath advertises a locally-originated prefix to peers and
+	// returns a PathHandle that can later be used to withdraw it.
+	AnnouncePath(route AnnouncedRoute) (PathHandle, error)
+	// Close stops watching and releases the resources held by the Session.
+	Close() error
+}
+
+// Watch represents an active subscription to speaker-originated events,
+// e.g. the one returned by Session.WatchBestPath.
+type Watch interface {
+	// Stop ends the subscription and waits for its goroutine to exit.
+	Stop()
+}
+
+// Path is a router-agnostic view of a single BGP path as observed by a
+// RouterManager driver, i.e. one element of a WatchBestPath event.
+type Path interface {
+	// UUID stably identifies this path across its lifetime, from the
+	// initial announcement through to its eventual withdrawal, regardless
+	// of whether the driver keeps the original path object alive. It is
+	// generated by the driver the first time the path is observed.
+	UUID() PathUUID
+	// IsWithdraw reports whether this Path event is the withdrawal of a
+	// previously announced path rather than a new announcement.
+	IsWithdraw() bool
+	// Route decodes the path's NLRI and attributes into a family-specific
+	// Route (IPv4UnicastRoute, IPv6UnicastRoute, VPNv4Route or EVPNRoute).
+	// It returns an error if the path's family is not supported.
+	Route() (Route, error)
+}
+
+// PathUUID stably identifies a BGP path across an announcement and any
+// later withdrawal of that same path, so that a subscriber can correlate
+// the two without holding onto a driver-specific object (e.g. a gobgp
+// *table.Path).
+type PathUUID string
+
+// PathHandle represents a path that this agent has itself originated via
+// Session.AnnouncePath. It lets the caller withdraw the path later without
+// reconstructing it.
+type PathHandle interface {
+	// UUID returns the stable identity of the originated path.
+	UUID() PathUUID
+	// Withdraw withdraws the originated path from all peers it was
+	// advertised to.
+	Withdraw() error
+}
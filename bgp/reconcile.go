@@ -0,0 +1,38 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+// ReconcileOp identifies what a reconciler did to converge a single piece of desired state.
+type ReconcileOp string
+
+const (
+	// ReconcileAdded means a neighbor present in the desired config was missing from the running
+	// session and was added.
+	ReconcileAdded ReconcileOp = "added"
+	// ReconcileUpdated means a neighbor present in both the desired and running config had
+	// conflicting settings and was deleted and re-added to converge.
+	ReconcileUpdated ReconcileOp = "updated"
+	// ReconcileDeleted means a neighbor present in the running session was missing from the
+	// desired config and was deleted.
+	ReconcileDeleted ReconcileOp = "deleted"
+)
+
+// ReconcileEvent reports the outcome of reconciling a single neighbor towards a newly supplied
+// desired configuration. Err is nil unless applying Op failed.
+type ReconcileEvent struct {
+	Neighbor string
+	Op       ReconcileOp
+	Err      error
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+// ReachableIPRoute represents a single reachable IP prefix as learned via a
+// BGP best-path update.
+type ReachableIPRoute struct {
+	// PathUUID stably identifies the BGP path that produced this route. It
+	// is assigned by the RouterManager driver the first time the path is
+	// observed and stays the same for any later event referring to that
+	// same path, so subscribers can correlate an announcement with a later
+	// withdrawal.
+	PathUUID PathUUID
+	As       uint32
+	Prefix   string
+	Nexthop  string
+}
+
+// AnnouncedRoute describes a locally-originated prefix to be advertised to
+// BGP peers via Session.AnnouncePath.
+type AnnouncedRoute struct {
+	// Prefix is the NLRI to advertise, in CIDR notation (e.g. "10.0.0.0/24").
+	Prefix string
+	// Nexthop is the next-hop address to advertise the prefix with.
+	Nexthop string
+	// Origin is the BGP ORIGIN path attribute value (IGP, EGP or INCOMPLETE,
+	// as per RFC 4271).
+	Origin uint8
+	// ASPrepend lists AS numbers to prepend to the AS-path, in the order
+	// they should appear closest to this agent's own AS.
+	ASPrepend []uint32
+	// Communities lists BGP community values to attach to the path.
+	Communities []uint32
+	// MED is the MULTI_EXIT_DISC path attribute value. Nil omits the attribute.
+	MED *uint32
+	// LocalPref is the LOCAL_PREF path attribute value. Nil omits the attribute.
+	LocalPref *uint32
+}
@@ -0,0 +1,32 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bgp
+
+import "github.com/osrg/gobgp/config"
+
+// Speaker is the top-level control surface for a BGP implementation,
+// whether it runs in-process (the gobgp driver in bgp/gobgp, which already
+// satisfies this interface through RouterManager) or out-of-process as a
+// child "bgp-speaker" binary reached over RPC (bgp/execbgp). Plugin's
+// AfterInit and Close are thin orchestrators around whichever Speaker
+// implementation is selected by configuration; everything the Speaker
+// returns (Session, Watch, Path, ...) behaves identically either way.
+type Speaker interface {
+	// Start brings up the BGP speaker and returns the active Session.
+	Start(global *config.Global) (Session, error)
+	// Stop tears down the speaker. For an out-of-process speaker this also
+	// terminates the child process.
+	Stop() error
+}
@@ -0,0 +1,34 @@
+// Copyright (c) 2017 Pantheon technologies s.r.o.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at:
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command bgp-speaker is the out-of-process BGP speaker binary launched by bgp/execbgp.NewSpeaker.
+// It wraps the in-process gobgp driver and exposes it to the agent process over hashicorp/go-plugin's
+// net/rpc transport, so that gobgp's crashes and resource usage are isolated from the agent and only
+// this binary needs privileges such as CAP_NET_BIND_SERVICE for port 179.
+package main
+
+import (
+	"github.com/hashicorp/go-plugin"
+	"github.com/ligato/bgp-agent/bgp/execbgp"
+	"github.com/ligato/bgp-agent/bgp/gobgp"
+)
+
+func main() {
+	plugin.Serve(&plugin.ServeConfig{
+		HandshakeConfig: execbgp.Handshake,
+		Plugins: map[string]plugin.Plugin{
+			"speaker": &execbgp.SpeakerPlugin{Manager: gobgp.NewRouterManager()},
+		},
+	})
+}